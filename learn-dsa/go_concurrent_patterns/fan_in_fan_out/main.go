@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/s1thu/dsa-golang/pkg/pipeline"
 )
 
 // generator produces data and sends it to a channel
@@ -93,4 +96,19 @@ func main() {
 	for result := range results {
 		fmt.Println("Result:", result)
 	}
+
+	fmt.Println()
+	fmt.Println("=== Ordered Fan-Out Demo ===")
+	fmt.Println("Same square work, but results come back in input order")
+	fmt.Println()
+
+	ctx := context.Background()
+	ordered := generator(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	orderedResults := pipeline.OrderedFanOut(ctx, ordered, numWorkers, func(n int) int {
+		time.Sleep(time.Duration(10-n) * 100 * time.Millisecond) // vary work so a naive fan-out would reorder
+		return n * n
+	})
+	for result := range orderedResults {
+		fmt.Println("Ordered result:", result)
+	}
 }
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"time"
+
+	"github.com/s1thu/dsa-golang/pkg/pipeline"
 )
 
 // Stage 1: Generate numbers
@@ -103,4 +106,39 @@ func main() {
 	for result := range stage3 {
 		fmt.Println("Generic Pipeline Result:", result)
 	}
+
+	fmt.Println()
+	fmt.Println("=== Throttled Pipeline Demo ===")
+	fmt.Println("Same square -> addTen -> double chain, paced to 2 items/sec so a slow")
+	fmt.Println("downstream consumer never gets flooded")
+	fmt.Println()
+
+	ctx := context.Background()
+	metrics := pipeline.NewStageMetrics()
+
+	p := pipeline.New(ctx, pipeline.FromSlice(1, 2, 3, 4, 5))
+	p = pipeline.Throttle(ctx, p, 2, time.Second)
+	p = pipeline.Map(ctx, p, pipeline.Metered(metrics, func(n int) (int, error) {
+		fmt.Printf("Squaring %d\n", n)
+		return n * n, nil
+	}))
+	p = pipeline.Map(ctx, p, func(n int) (int, error) {
+		fmt.Printf("Adding 10 to %d\n", n)
+		return n + 10, nil
+	})
+	p = pipeline.Map(ctx, p, func(n int) (int, error) {
+		fmt.Printf("Doubling %d\n", n)
+		return n * 2, nil
+	})
+
+	var results []int
+	if err := p.Run(ctx, pipeline.Collect(&results)); err != nil {
+		fmt.Println("Pipeline error:", err)
+	}
+	for _, r := range results {
+		fmt.Println("Throttled Result:", r)
+	}
+
+	snap := metrics.Snapshot()
+	fmt.Printf("Square stage: %d calls, p50=%s, p99=%s\n", snap.Count, snap.P50, snap.P99)
 }
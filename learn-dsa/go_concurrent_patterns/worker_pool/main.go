@@ -1,42 +1,58 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"sync"
 	"time"
-)
 
-func worker(id int, jobs <-chan int, results chan<- int, wg *sync.WaitGroup) {
-	defer wg.Done()
-	for job := range jobs {
-		fmt.Printf("Worker %d started job %d\n", id, job)
-		time.Sleep(time.Second * 10)
-		fmt.Printf("Worker %d finished job %d\n", id, job)
-		results <- job * 2
-	}
-}
+	"github.com/s1thu/dsa-golang/pkg/workerpool"
+)
 
 func main() {
 	const numJobs = 5
-	const numWorkers = 3
-	jobs := make(chan int, numJobs)
-	results := make(chan int, numJobs)
-	var wg sync.WaitGroup
-
-	for i := 1; i <= numWorkers; i++ {
-		wg.Add(1)
-		go worker(i, jobs, results, &wg)
-	}
 
+	pool := workerpool.New[int, int](workerpool.Config{
+		Workers:     3,
+		QueueSize:   numJobs,
+		JobTimeout:  5 * time.Second,
+		MaxRetries:  2,
+		BaseBackoff: 100 * time.Millisecond,
+	})
+
+	futures := make([]*workerpool.Future[int], numJobs)
 	for j := 1; j <= numJobs; j++ {
-		jobs <- j
+		job := workerpool.Job[int, int]{
+			Input: j,
+			Fn:    double,
+		}
+		futures[j-1] = pool.Submit(context.Background(), job)
 	}
-	close(jobs)
-
-	wg.Wait()
-	close(results)
 
-	for result := range results {
+	for i, f := range futures {
+		result, err := f.Wait()
+		if err != nil {
+			fmt.Printf("Job %d failed: %v\n", i+1, err)
+			continue
+		}
 		fmt.Println("Result:", result)
 	}
+
+	m := pool.Metrics()
+	fmt.Printf("Completed: %d, Failed: %d\n", m.Completed, m.Failed)
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		fmt.Println("Shutdown error:", err)
+	}
+}
+
+func double(ctx context.Context, job int) (int, error) {
+	fmt.Printf("Processing job %d\n", job)
+	select {
+	case <-time.After(time.Second):
+	case <-ctx.Done():
+		return 0, errors.New("job canceled")
+	}
+	fmt.Printf("Finished job %d\n", job)
+	return job * 2, nil
 }
@@ -1,51 +1,55 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
 
-//heap struct that hold the array
-type maxHeap struct {
-	array []int
-}
+	"github.com/s1thu/dsa-golang/pkg/heap"
+)
 
-//insert
-func (h *maxHeap) insert(key int) {
-	h.array = append(h.array, key)
-	fmt.Println("Length of array:", len(h.array))
-	h.maxHeapifyUp(len(h.array) - 1)
-}
+func main() {
+	fmt.Println("=== Build Max-Heap Demo ===")
+	values := []int{50, 30, 20, 40, 10, 60, 70}
+	h := heap.BuildHeap(values, func(a, b int) bool { return a > b })
+	fmt.Println("Heapified:", values)
 
-func (h *maxHeap) maxHeapifyUp(index int) {
-	for h.array[h.getParentIndex(index)] < h.array[index] {
-		h.swap(h.getParentIndex(index), index)
-		index = h.getParentIndex(index)
-	}
-}
+	top, _ := h.Peek()
+	fmt.Println("Peek (max):", top)
 
-//get parent index
-func (h *maxHeap) getParentIndex(index int) int {
-	return (index - 1) / 2
-}
+	h.Push(65)
+	fmt.Println("After push 65, peek:", mustPeek(h))
 
-//right child index always be even number
-func (h *maxHeap) rightIndex(index int) int {
-	return 2*index + 2
-}
+	h.DecreaseKey(0, 5)
+	fmt.Println("After decreasing the max to 5, peek:", mustPeek(h))
 
-//left child index always be odd number
-func (h *maxHeap) leftIndex(index int) int {
-	return 2*index + 1
-}
+	fmt.Println()
+	fmt.Println("=== Extract in descending order ===")
+	for h.Len() > 0 {
+		v, _ := h.ExtractMax()
+		fmt.Println("Extracted:", v)
+	}
 
-func (h *maxHeap) swap(index1, index2 int) {
-	h.array[index1], h.array[index2] = h.array[index2], h.array[index1]
+	fmt.Println()
+	fmt.Println("=== MergeK Demo ===")
+	a := sortedChan(1, 4, 7)
+	b := sortedChan(2, 3, 9)
+	c := sortedChan(5, 6, 8)
+	for v := range heap.MergeK(func(x, y int) bool { return x < y }, a, b, c) {
+		fmt.Println("Merged:", v)
+	}
 }
 
-func main() {
-	m := &maxHeap{}
+func mustPeek(h *heap.Heap[int]) int {
+	v, _ := h.Peek()
+	return v
+}
 
-	buildHeap := []int{50, 30, 20, 40, 10, 60, 70}
-	for _, v := range buildHeap {
-		m.insert(v)
-		fmt.Println(m)
-	}
+func sortedChan(vals ...int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for _, v := range vals {
+			out <- v
+		}
+	}()
+	return out
 }
@@ -0,0 +1,131 @@
+// Package heap implements a generic binary heap. Unlike container/heap it
+// needs no interface boilerplate from callers: a Heap is built around a
+// Less func, and NewOrdered gives a max-heap for free on any cmp.Ordered
+// type.
+package heap
+
+import "cmp"
+
+// Heap is a binary heap over T, ordered by less. less(a, b) reporting true
+// means a should come out before b, so a max-heap uses less(a, b) = a > b.
+type Heap[T any] struct {
+	data []T
+	less func(a, b T) bool
+}
+
+// New returns an empty heap ordered by less.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// NewOrdered returns an empty max-heap over an ordered type.
+func NewOrdered[T cmp.Ordered]() *Heap[T] {
+	return New(func(a, b T) bool { return a > b })
+}
+
+// BuildHeap heapifies vals in place and returns a heap backed by it, using
+// Floyd's O(n) sift-down algorithm instead of n repeated Pushes.
+func BuildHeap[T any](vals []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{data: vals, less: less}
+	for i := len(h.data)/2 - 1; i >= 0; i-- {
+		h.siftDown(i)
+	}
+	return h
+}
+
+// Len reports the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.data)
+}
+
+// Peek returns the top element without removing it.
+func (h *Heap[T]) Peek() (T, bool) {
+	var zero T
+	if len(h.data) == 0 {
+		return zero, false
+	}
+	return h.data[0], true
+}
+
+// Push inserts v into the heap.
+func (h *Heap[T]) Push(v T) {
+	h.data = append(h.data, v)
+	h.siftUp(len(h.data) - 1)
+}
+
+// Pop removes and returns the top element. It is also known as ExtractMax
+// when the heap is ordered as a max-heap.
+func (h *Heap[T]) Pop() (T, bool) {
+	return h.Remove(0)
+}
+
+// ExtractMax is an alias for Pop, for callers using the heap as a max-heap.
+func (h *Heap[T]) ExtractMax() (T, bool) {
+	return h.Pop()
+}
+
+// Remove removes and returns the element at index i.
+func (h *Heap[T]) Remove(i int) (T, bool) {
+	var zero T
+	n := len(h.data)
+	if i < 0 || i >= n {
+		return zero, false
+	}
+	removed := h.data[i]
+	last := n - 1
+	h.swap(i, last)
+	h.data = h.data[:last]
+	if i < last {
+		h.siftDown(i)
+		h.siftUp(i)
+	}
+	return removed, true
+}
+
+// DecreaseKey updates the value at index i, which must compare lower under
+// less (further from the top for a max-heap), and restores heap order.
+func (h *Heap[T]) DecreaseKey(i int, newValue T) {
+	h.data[i] = newValue
+	h.siftDown(i)
+}
+
+// IncreaseKey updates the value at index i, which must compare higher under
+// less (closer to the top for a max-heap), and restores heap order.
+func (h *Heap[T]) IncreaseKey(i int, newValue T) {
+	h.data[i] = newValue
+	h.siftUp(i)
+}
+
+func (h *Heap[T]) siftUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.data[i], h.data[parent]) {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+func (h *Heap[T]) siftDown(i int) {
+	n := len(h.data)
+	for {
+		left, right := 2*i+1, 2*i+2
+		top := i
+		if left < n && h.less(h.data[left], h.data[top]) {
+			top = left
+		}
+		if right < n && h.less(h.data[right], h.data[top]) {
+			top = right
+		}
+		if top == i {
+			break
+		}
+		h.swap(i, top)
+		i = top
+	}
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+}
@@ -0,0 +1,36 @@
+package heap
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func lessInt(a, b int) bool { return a > b }
+
+func randInts(n int) []int {
+	r := rand.New(rand.NewSource(1))
+	vals := make([]int, n)
+	for i := range vals {
+		vals[i] = r.Intn(1 << 30)
+	}
+	return vals
+}
+
+// BenchmarkBuildHeap measures the O(n) Floyd's-algorithm path.
+func BenchmarkBuildHeap(b *testing.B) {
+	vals := randInts(b.N)
+	b.ResetTimer()
+	BuildHeap(vals, lessInt)
+}
+
+// BenchmarkRepeatedPush measures building the same heap via b.N individual
+// Push calls, each an O(log n) sift-up, i.e. the O(n log n) path BuildHeap
+// is meant to avoid.
+func BenchmarkRepeatedPush(b *testing.B) {
+	vals := randInts(b.N)
+	h := New(lessInt)
+	b.ResetTimer()
+	for _, v := range vals {
+		h.Push(v)
+	}
+}
@@ -0,0 +1,183 @@
+package heap
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestPushPopSorted(t *testing.T) {
+	vals := []int{5, 3, 8, 1, 9, 2, 7}
+	h := New(lessInt)
+	for _, v := range vals {
+		h.Push(v)
+	}
+
+	want := append([]int(nil), vals...)
+	sort.Sort(sort.Reverse(sort.IntSlice(want)))
+
+	var got []int
+	for h.Len() > 0 {
+		v, ok := h.Pop()
+		if !ok {
+			t.Fatalf("Pop returned ok=false with Len()=%d remaining", h.Len())
+		}
+		got = append(got, v)
+	}
+
+	if !equalInts(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPeekDoesNotRemove(t *testing.T) {
+	h := New(lessInt)
+	h.Push(3)
+	h.Push(10)
+	h.Push(7)
+
+	top, ok := h.Peek()
+	if !ok || top != 10 {
+		t.Fatalf("Peek() = %v, %v; want 10, true", top, ok)
+	}
+	if h.Len() != 3 {
+		t.Errorf("Peek changed Len() to %d, want 3", h.Len())
+	}
+	top, _ = h.Peek()
+	if top != 10 {
+		t.Errorf("second Peek() = %v, want 10", top)
+	}
+}
+
+func TestBuildHeap(t *testing.T) {
+	vals := []int{50, 30, 20, 40, 10, 60, 70}
+	h := BuildHeap(vals, lessInt)
+
+	if h.Len() != len(vals) {
+		t.Fatalf("Len() = %d, want %d", h.Len(), len(vals))
+	}
+	top, _ := h.Peek()
+	if top != 70 {
+		t.Errorf("Peek() = %d, want 70", top)
+	}
+
+	want := []int{70, 60, 50, 40, 30, 20, 10}
+	var got []int
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		got = append(got, v)
+	}
+	if !equalInts(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDecreaseKey(t *testing.T) {
+	h := New(lessInt)
+	for _, v := range []int{10, 20, 30, 40, 50} {
+		h.Push(v)
+	}
+	top, _ := h.Peek()
+	if top != 50 {
+		t.Fatalf("Peek() = %d, want 50", top)
+	}
+
+	h.DecreaseKey(0, 5)
+	top, _ = h.Peek()
+	if top != 40 {
+		t.Errorf("after DecreaseKey, Peek() = %d, want 40", top)
+	}
+}
+
+func TestIncreaseKey(t *testing.T) {
+	h := New(lessInt)
+	for _, v := range []int{10, 20, 30, 40, 50} {
+		h.Push(v)
+	}
+
+	// Find the index holding 10 and raise it above the current max.
+	idx := -1
+	for i, v := range h.data {
+		if v == 10 {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		t.Fatalf("value 10 not found in heap data %v", h.data)
+	}
+
+	h.IncreaseKey(idx, 100)
+	top, _ := h.Peek()
+	if top != 100 {
+		t.Errorf("after IncreaseKey, Peek() = %d, want 100", top)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	h := New(lessInt)
+	for _, v := range []int{10, 20, 30, 40, 50} {
+		h.Push(v)
+	}
+
+	removed, ok := h.Remove(0)
+	if !ok || removed != 50 {
+		t.Fatalf("Remove(0) = %v, %v; want 50, true", removed, ok)
+	}
+	top, _ := h.Peek()
+	if top != 40 {
+		t.Errorf("after removing the max, Peek() = %d, want 40", top)
+	}
+
+	want := []int{40, 30, 20, 10}
+	var got []int
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		got = append(got, v)
+	}
+	if !equalInts(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, ok := h.Remove(0); ok {
+		t.Errorf("Remove(0) on an empty heap returned ok=true")
+	}
+}
+
+func TestMergeK(t *testing.T) {
+	a := sliceChan(1, 4, 7)
+	b := sliceChan(2, 3, 9)
+	c := sliceChan(5, 6, 8)
+
+	var got []int
+	for v := range MergeK(func(x, y int) bool { return x < y }, a, b, c) {
+		got = append(got, v)
+	}
+
+	want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if !equalInts(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func sliceChan(vals ...int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for _, v := range vals {
+			out <- v
+		}
+	}()
+	return out
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
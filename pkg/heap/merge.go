@@ -0,0 +1,36 @@
+package heap
+
+// MergeK merges k already-sorted channels into a single sorted output
+// channel, using a Heap to track which channel's next value is smallest
+// (per less). It is a natural bridge to pipeline.FanIn: where FanIn just
+// interleaves results, MergeK restores a total order across the merged
+// streams.
+func MergeK[T any](less func(a, b T) bool, chans ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	type item struct {
+		val T
+		src int
+	}
+	h := New(func(a, b item) bool { return less(a.val, b.val) })
+
+	go func() {
+		defer close(out)
+
+		for i, ch := range chans {
+			if v, ok := <-ch; ok {
+				h.Push(item{val: v, src: i})
+			}
+		}
+
+		for h.Len() > 0 {
+			next, _ := h.Pop()
+			out <- next.val
+			if v, ok := <-chans[next.src]; ok {
+				h.Push(item{val: v, src: next.src})
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,239 @@
+package pipeline
+
+import "context"
+
+// Map applies fn to every successful value, replacing it in place. Errors
+// (from fn or from upstream) pass through untouched. buf optionally sets
+// the output channel's buffer size for backpressure tuning; it defaults to
+// unbuffered.
+func Map[T, U any](ctx context.Context, p *Pipeline[T], fn func(T) (U, error), buf ...int) *Pipeline[U] {
+	out := make(chan Result[U], bufSize(buf))
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case r, ok := <-p.out:
+				if !ok {
+					return
+				}
+				var next Result[U]
+				if r.Err != nil {
+					next = Result[U]{Err: r.Err}
+				} else {
+					v, err := fn(r.Value)
+					next = Result[U]{Value: v, Err: err}
+				}
+				select {
+				case out <- next:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return &Pipeline[U]{out: out}
+}
+
+// Filter drops successful values for which pred returns false. Errors
+// always pass through so a failing upstream item is never silently lost.
+func Filter[T any](ctx context.Context, p *Pipeline[T], pred func(T) bool, buf ...int) *Pipeline[T] {
+	out := make(chan Result[T], bufSize(buf))
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case r, ok := <-p.out:
+				if !ok {
+					return
+				}
+				if r.Err != nil || pred(r.Value) {
+					select {
+					case out <- r:
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return &Pipeline[T]{out: out}
+}
+
+// FlatMap expands each successful value into zero or more U values.
+func FlatMap[T, U any](ctx context.Context, p *Pipeline[T], fn func(T) ([]U, error), buf ...int) *Pipeline[U] {
+	out := make(chan Result[U], bufSize(buf))
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case r, ok := <-p.out:
+				if !ok {
+					return
+				}
+				if r.Err != nil {
+					select {
+					case out <- Result[U]{Err: r.Err}:
+					case <-ctx.Done():
+						return
+					}
+					continue
+				}
+				vs, err := fn(r.Value)
+				if err != nil {
+					select {
+					case out <- Result[U]{Err: err}:
+					case <-ctx.Done():
+						return
+					}
+					continue
+				}
+				for _, v := range vs {
+					select {
+					case out <- Ok(v):
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return &Pipeline[U]{out: out}
+}
+
+// Batch groups successful values into slices of size n, emitting a
+// short final batch for any remainder. An error is emitted as soon as it
+// is seen and flushes whatever partial batch was pending.
+func Batch[T any](ctx context.Context, p *Pipeline[T], n int, buf ...int) *Pipeline[[]T] {
+	if n <= 0 {
+		n = 1
+	}
+	out := make(chan Result[[]T], bufSize(buf))
+	go func() {
+		defer close(out)
+		batch := make([]T, 0, n)
+		send := func(r Result[[]T]) bool {
+			select {
+			case out <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for {
+			select {
+			case r, ok := <-p.out:
+				if !ok {
+					if len(batch) > 0 {
+						send(Ok(batch))
+					}
+					return
+				}
+				if r.Err != nil {
+					if len(batch) > 0 {
+						if !send(Ok(batch)) {
+							return
+						}
+						batch = make([]T, 0, n)
+					}
+					if !send(Result[[]T]{Err: r.Err}) {
+						return
+					}
+					continue
+				}
+				batch = append(batch, r.Value)
+				if len(batch) == n {
+					if !send(Ok(batch)) {
+						return
+					}
+					batch = make([]T, 0, n)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return &Pipeline[[]T]{out: out}
+}
+
+// FanOut splits p's output across n worker channels in round-robin order,
+// returning one *Pipeline[T] per worker. Merging them back with FanIn does
+// not restore the original ordering; OrderedFanOut processes a stream
+// concurrently while keeping its input order.
+func FanOut[T any](ctx context.Context, p *Pipeline[T], n int) []*Pipeline[T] {
+	outs := make([]chan Result[T], n)
+	pipelines := make([]*Pipeline[T], n)
+	for i := range outs {
+		outs[i] = make(chan Result[T])
+		pipelines[i] = &Pipeline[T]{out: outs[i]}
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		i := 0
+		for {
+			select {
+			case r, ok := <-p.out:
+				if !ok {
+					return
+				}
+				select {
+				case outs[i%n] <- r:
+					i++
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pipelines
+}
+
+// FanIn merges several pipelines into one, interleaving results as they
+// arrive. Input order across the merged streams is not preserved.
+func FanIn[T any](ctx context.Context, ps ...*Pipeline[T]) *Pipeline[T] {
+	out := make(chan Result[T])
+	done := make(chan struct{}, len(ps))
+
+	for _, p := range ps {
+		go func(p *Pipeline[T]) {
+			defer func() { done <- struct{}{} }()
+			for {
+				select {
+				case r, ok := <-p.out:
+					if !ok {
+						return
+					}
+					select {
+					case out <- r:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(p)
+	}
+
+	go func() {
+		defer close(out)
+		for range ps {
+			<-done
+		}
+	}()
+
+	return &Pipeline[T]{out: out}
+}
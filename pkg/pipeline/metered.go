@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// StageMetrics accumulates per-call latency samples for a stage so
+// throughput and tail latency can be observed from the outside. Samples are
+// kept in a fixed-size ring buffer so long-running pipelines don't grow it
+// unbounded; once full, the oldest sample is overwritten by the newest.
+type StageMetrics struct {
+	mu      sync.Mutex
+	count   int64
+	samples []time.Duration
+	next    int // ring buffer write position, wraps at stageMetricsWindow
+	first   time.Time
+	last    time.Time
+}
+
+const stageMetricsWindow = 1000
+
+// NewStageMetrics returns an empty StageMetrics.
+func NewStageMetrics() *StageMetrics {
+	return &StageMetrics{samples: make([]time.Duration, 0, stageMetricsWindow)}
+}
+
+func (m *StageMetrics) observe(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if m.count == 0 {
+		m.first = now
+	}
+	m.last = now
+	m.count++
+	if len(m.samples) < stageMetricsWindow {
+		m.samples = append(m.samples, d)
+	} else {
+		m.samples[m.next] = d
+		m.next = (m.next + 1) % stageMetricsWindow
+	}
+}
+
+// StageSnapshot is a point-in-time read of a StageMetrics.
+type StageSnapshot struct {
+	Count      int64
+	Throughput float64 // items per second since the first observation
+	P50        time.Duration
+	P99        time.Duration
+}
+
+// Snapshot returns the current throughput, computed over every observation
+// since the first, and tail latency, computed over the most recent
+// stageMetricsWindow samples.
+func (m *StageMetrics) Snapshot() StageSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snap := StageSnapshot{Count: m.count}
+	if m.count == 0 {
+		return snap
+	}
+	if elapsed := m.last.Sub(m.first).Seconds(); elapsed > 0 {
+		snap.Throughput = float64(m.count) / elapsed
+	}
+
+	sorted := append([]time.Duration(nil), m.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	snap.P50 = percentile(sorted, 0.50)
+	snap.P99 = percentile(sorted, 0.99)
+	return snap
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+// Metered wraps a transform function (as used with Map, FlatMap, etc.) so
+// every call's duration is recorded in m.
+func Metered[T, U any](m *StageMetrics, fn func(T) (U, error)) func(T) (U, error) {
+	return func(v T) (U, error) {
+		start := time.Now()
+		out, err := fn(v)
+		m.observe(time.Since(start))
+		return out, err
+	}
+}
@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMeteredRecordsDurationAndPassesThrough(t *testing.T) {
+	m := NewStageMetrics()
+	fn := Metered(m, func(n int) (int, error) {
+		time.Sleep(time.Millisecond)
+		return n * 2, nil
+	})
+
+	out, err := fn(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != 6 {
+		t.Errorf("got %d, want 6", out)
+	}
+
+	snap := m.Snapshot()
+	if snap.Count != 1 {
+		t.Fatalf("got Count=%d, want 1", snap.Count)
+	}
+
+	boom := errors.New("boom")
+	fn = Metered(m, func(n int) (int, error) { return 0, boom })
+	if _, err := fn(1); !errors.Is(err, boom) {
+		t.Fatalf("got error %v, want %v", err, boom)
+	}
+	if snap := m.Snapshot(); snap.Count != 2 {
+		t.Errorf("got Count=%d after errored call, want 2", snap.Count)
+	}
+}
+
+// TestStageMetricsRingBufferWraparound observes more than stageMetricsWindow
+// samples and checks that old samples are overwritten rather than the
+// backing slice growing without bound, and that the snapshot only reflects
+// the most recent stageMetricsWindow samples.
+func TestStageMetricsRingBufferWraparound(t *testing.T) {
+	m := NewStageMetrics()
+
+	for i := 0; i < stageMetricsWindow; i++ {
+		m.observe(time.Millisecond)
+	}
+	if got := len(m.samples); got != stageMetricsWindow {
+		t.Fatalf("after filling the window, len(samples)=%d, want %d", got, stageMetricsWindow)
+	}
+
+	// Overwrite every sample with a much larger duration; if wraparound were
+	// broken (e.g. appending instead of overwriting) the backing slice would
+	// grow past stageMetricsWindow.
+	for i := 0; i < stageMetricsWindow; i++ {
+		m.observe(time.Second)
+	}
+	if got := len(m.samples); got != stageMetricsWindow {
+		t.Fatalf("after wrapping once, len(samples)=%d, want %d", got, stageMetricsWindow)
+	}
+	if got := m.count; got != int64(2*stageMetricsWindow) {
+		t.Fatalf("got count=%d, want %d", got, 2*stageMetricsWindow)
+	}
+
+	snap := m.Snapshot()
+	if snap.P50 != time.Second || snap.P99 != time.Second {
+		t.Errorf("got P50=%v P99=%v, want both %v since every retained sample was overwritten", snap.P50, snap.P99, time.Second)
+	}
+}
@@ -0,0 +1,139 @@
+package pipeline
+
+import (
+	"container/heap"
+	"context"
+)
+
+// seqItem pairs a value with the sequence number it was read from in. It is
+// used to restore input order after concurrent processing.
+type seqItem[T any] struct {
+	seq int
+	val T
+}
+
+// seqHeap is a min-heap of seqItem ordered by seq, used by OrderedFanOut to
+// buffer results until the next expected sequence number is ready.
+type seqHeap[T any] []seqItem[T]
+
+func (h seqHeap[T]) Len() int            { return len(h) }
+func (h seqHeap[T]) Less(i, j int) bool  { return h[i].seq < h[j].seq }
+func (h seqHeap[T]) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *seqHeap[T]) Push(x interface{}) { *h = append(*h, x.(seqItem[T])) }
+func (h *seqHeap[T]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// OrderedFanOut processes in across n concurrent workers running fn, but
+// re-establishes the original input order on the output channel. Each
+// input value is tagged with a monotonically increasing sequence number
+// before it is dispatched to a worker; a single merger goroutine holds a
+// min-heap of out-of-order results and only emits the next expected
+// sequence, buffering the rest until their predecessor arrives.
+func OrderedFanOut[T, U any](ctx context.Context, in <-chan T, n int, fn func(T) U) <-chan U {
+	if n <= 0 {
+		n = 1
+	}
+
+	tagged := make(chan seqItem[T])
+	go func() {
+		defer close(tagged)
+		seq := 0
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case tagged <- seqItem[T]{seq: seq, val: v}:
+					seq++
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make(chan seqItem[U])
+	workerIn := make([]chan seqItem[T], n)
+	for i := range workerIn {
+		workerIn[i] = make(chan seqItem[T])
+	}
+
+	// Dispatch round-robin so workers stay busy while sequence order is
+	// preserved for the merger to restore later.
+	go func() {
+		defer func() {
+			for _, ch := range workerIn {
+				close(ch)
+			}
+		}()
+		i := 0
+		for item := range tagged {
+			select {
+			case workerIn[i%n] <- item:
+				i++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	doneWorkers := make(chan struct{}, n)
+	for _, wi := range workerIn {
+		go func(wi <-chan seqItem[T]) {
+			defer func() { doneWorkers <- struct{}{} }()
+			for item := range wi {
+				out := seqItem[U]{seq: item.seq, val: fn(item.val)}
+				select {
+				case results <- out:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(wi)
+	}
+	go func() {
+		defer close(results)
+		for range workerIn {
+			<-doneWorkers
+		}
+	}()
+
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		pending := &seqHeap[U]{}
+		next := 0
+	merge:
+		for {
+			select {
+			case item, ok := <-results:
+				if !ok {
+					break merge
+				}
+				heap.Push(pending, item)
+			case <-ctx.Done():
+				return
+			}
+			for pending.Len() > 0 && (*pending)[0].seq == next {
+				ready := heap.Pop(pending).(seqItem[U])
+				select {
+				case out <- ready.val:
+				case <-ctx.Done():
+					return
+				}
+				next++
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,139 @@
+// Package pipeline provides a small generic building-block library for
+// streaming data through a chain of concurrent stages. Every stage is
+// cancellation-aware (it selects on ctx.Done() alongside its input channel)
+// and failures propagate downstream as values rather than panics, so a
+// broken stage drains cleanly instead of leaking goroutines.
+package pipeline
+
+import "context"
+
+// Result carries either a value or an error through the pipeline. Once an
+// Err is set for an item, downstream stages pass it through unchanged
+// instead of applying their own transform to it.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Ok wraps a value as a successful Result.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{Value: v}
+}
+
+// Errf wraps an error as a failed Result.
+func Errf[T any](err error) Result[T] {
+	return Result[T]{Err: err}
+}
+
+// Source produces a stream of results for a pipeline to consume.
+type Source[T any] func(ctx context.Context) <-chan Result[T]
+
+// Stage transforms a stream of T results into a stream of U results.
+type Stage[T, U any] func(ctx context.Context, in <-chan Result[T]) <-chan Result[U]
+
+// Sink drains a stream, returning the first error encountered (if any).
+type Sink[T any] func(ctx context.Context, in <-chan Result[T]) error
+
+// Pipeline wraps a single channel of in-flight results. Combinators such as
+// Map and Filter consume a *Pipeline[T] and return a *Pipeline[U], so a
+// pipeline is built up by repeated reassignment:
+//
+//	p := pipeline.New(ctx, pipeline.FromSlice(1, 2, 3))
+//	p = pipeline.Map(ctx, p, func(n int) (int, error) { return n * n, nil })
+//	err := p.Run(ctx, pipeline.Collect(&out))
+type Pipeline[T any] struct {
+	out <-chan Result[T]
+}
+
+// New starts src and returns the pipeline reading from it.
+func New[T any](ctx context.Context, src Source[T]) *Pipeline[T] {
+	return &Pipeline[T]{out: src(ctx)}
+}
+
+// Out exposes the underlying channel so custom stages can be spliced in
+// without going through the combinator helpers.
+func (p *Pipeline[T]) Out() <-chan Result[T] {
+	return p.out
+}
+
+// Run drains the pipeline through sink and returns its error, if any.
+//
+// ctx should be the same cancelable context passed to New and every
+// combinator used to build the pipeline, and the caller should cancel it
+// (typically via a deferred cancel right after Run returns) even on the
+// success path: if sink returns early, for example because Collect saw an
+// error partway through, canceling ctx is what tells the remaining stages
+// to stop trying to send and exit instead of blocking forever.
+func (p *Pipeline[T]) Run(ctx context.Context, sink Sink[T]) error {
+	return sink(ctx, p.out)
+}
+
+// FromSlice is a Source that emits each element of vals in order.
+func FromSlice[T any](vals ...T) Source[T] {
+	return func(ctx context.Context) <-chan Result[T] {
+		out := make(chan Result[T])
+		go func() {
+			defer close(out)
+			for _, v := range vals {
+				select {
+				case out <- Ok(v):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return out
+	}
+}
+
+// Collect appends every successful value from the pipeline into dst and
+// returns the first error encountered, if any. It is meant to be used as a
+// Sink via (*Pipeline[T]).Run.
+func Collect[T any](dst *[]T) Sink[T] {
+	return func(ctx context.Context, in <-chan Result[T]) error {
+		for {
+			select {
+			case r, ok := <-in:
+				if !ok {
+					return nil
+				}
+				if r.Err != nil {
+					return r.Err
+				}
+				*dst = append(*dst, r.Value)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// Discard drains the pipeline without collecting values, returning the
+// first error encountered, if any.
+func Discard[T any]() Sink[T] {
+	return func(ctx context.Context, in <-chan Result[T]) error {
+		for {
+			select {
+			case r, ok := <-in:
+				if !ok {
+					return nil
+				}
+				if r.Err != nil {
+					return r.Err
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// bufSize returns the first element of buf, or 0 (unbuffered) if empty.
+// Combinators accept an optional trailing buffer size this way so callers
+// don't have to thread a buffered/unbuffered choice through every call.
+func bufSize(buf []int) int {
+	if len(buf) == 0 {
+		return 0
+	}
+	return buf[0]
+}
@@ -0,0 +1,162 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestMapFilter(t *testing.T) {
+	ctx := context.Background()
+	p := New(ctx, FromSlice(1, 2, 3, 4, 5))
+	p = Map(ctx, p, func(n int) (int, error) { return n * n, nil })
+	p = Filter(ctx, p, func(n int) bool { return n%2 == 0 })
+
+	var got []int
+	if err := p.Run(ctx, Collect(&got)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []int{4, 16}
+	if !equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMapPropagatesError(t *testing.T) {
+	// Collect returns as soon as it sees an error, so this ctx must be
+	// canceled afterwards or the Map stage's goroutine would block forever
+	// trying to hand later items to a sink that already stopped reading.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	boom := errors.New("boom")
+	p := New(ctx, FromSlice(1, 2, 3))
+	p = Map(ctx, p, func(n int) (int, error) {
+		if n == 2 {
+			return 0, boom
+		}
+		return n, nil
+	})
+
+	var got []int
+	err := p.Run(ctx, Collect(&got))
+	if !errors.Is(err, boom) {
+		t.Fatalf("got error %v, want %v", err, boom)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	ctx := context.Background()
+	p := New(ctx, FromSlice(1, 2, 3))
+	p = FlatMap(ctx, p, func(n int) ([]int, error) { return []int{n, n}, nil })
+
+	var got []int
+	if err := p.Run(ctx, Collect(&got)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []int{1, 1, 2, 2, 3, 3}
+	if !equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	p := New(ctx, FromSlice(1, 2, 3, 4, 5))
+	batched := Batch(ctx, p, 2)
+
+	var got [][]int
+	if err := batched.Run(ctx, Collect(&got)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]int{{1, 2}, {3, 4}, {5}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v batches, want %v", got, want)
+	}
+	for i := range want {
+		if !equal(got[i], want[i]) {
+			t.Errorf("batch %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFanOutFanIn(t *testing.T) {
+	ctx := context.Background()
+	p := New(ctx, FromSlice(1, 2, 3, 4, 5, 6))
+	workers := FanOut(ctx, p, 3)
+	merged := FanIn(ctx, workers...)
+
+	var got []int
+	if err := merged.Run(ctx, Collect(&got)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Ints(got)
+	want := []int{1, 2, 3, 4, 5, 6}
+	if !equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestOrderedFanOut(t *testing.T) {
+	ctx := context.Background()
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for _, n := range []int{1, 2, 3, 4, 5} {
+			in <- n
+		}
+	}()
+
+	out := OrderedFanOut(ctx, in, 3, func(n int) int {
+		time.Sleep(time.Duration(5-n) * time.Millisecond) // vary latency so a naive fan-out would reorder
+		return n * n
+	})
+
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	want := []int{1, 4, 9, 16, 25}
+	if !equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// TestMapCancellationDoesNotLeak guards against a Map stage blocking
+// forever on a send once the consumer stops reading because ctx was
+// canceled.
+func TestMapCancellationDoesNotLeak(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan Result[int])
+	p := Map(ctx, &Pipeline[int]{out: in}, func(n int) (int, error) { return n, nil })
+
+	go func() { in <- Ok(1) }()
+	<-p.Out() // read the one item the consumer will ever read
+
+	cancel()
+	time.Sleep(50 * time.Millisecond) // let Map's goroutine observe ctx.Done
+
+	if err := goleak.Find(); err != nil {
+		t.Fatalf("Map goroutine leaked after cancellation: %v", err)
+	}
+}
+
+func equal[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a minimal in-repo token bucket: tokens refill continuously
+// at rate/per and cap out at rate, so bursts up to rate are allowed before
+// callers start waiting.
+type tokenBucket struct {
+	mu      sync.Mutex
+	tokens  float64
+	cap     float64
+	perSec  float64
+	lastRef time.Time
+}
+
+func newTokenBucket(rate int, per time.Duration) *tokenBucket {
+	perSec := float64(rate) / per.Seconds()
+	return &tokenBucket{
+		tokens:  float64(rate),
+		cap:     float64(rate),
+		perSec:  perSec,
+		lastRef: time.Now(),
+	}
+}
+
+// setRate changes the bucket's rate without resetting its current token
+// balance, so AdaptiveThrottle can retune smoothly.
+func (b *tokenBucket) setRate(rate int, per time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cap = float64(rate)
+	b.perSec = float64(rate) / per.Seconds()
+	if b.tokens > b.cap {
+		b.tokens = b.cap
+	}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.lastRef).Seconds() * b.perSec
+		if b.tokens > b.cap {
+			b.tokens = b.cap
+		}
+		b.lastRef = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		if b.perSec <= 0 {
+			b.mu.Unlock()
+			return fmt.Errorf("pipeline: throttle rate must be positive, got %g per second", b.perSec)
+		}
+		deficit := 1 - b.tokens
+		wait := time.Duration(deficit / b.perSec * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Throttle paces p's output to at most rate items per per, using a token
+// bucket so short bursts up to rate are allowed. It is meant to sit in
+// front of a slow or rate-limited downstream consumer so producers don't
+// have to know how fast that consumer can go.
+func Throttle[T any](ctx context.Context, p *Pipeline[T], rate int, per time.Duration, buf ...int) *Pipeline[T] {
+	bucket := newTokenBucket(rate, per)
+	out := make(chan Result[T], bufSize(buf))
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case r, ok := <-p.out:
+				if !ok {
+					return
+				}
+				if r.Err == nil {
+					if err := bucket.wait(ctx); err != nil {
+						r = Result[T]{Err: err}
+					}
+				}
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return &Pipeline[T]{out: out}
+}
+
+// AdaptiveThrottleConfig tunes AdaptiveThrottle's starting rate and the
+// bounds it is allowed to settle between.
+type AdaptiveThrottleConfig struct {
+	InitialRate   int
+	Per           time.Duration
+	MinRate       int
+	MaxRate       int
+	TargetLatency time.Duration // desired time to hand an item to the consumer
+	EWMAAlpha     float64       // smoothing factor for the latency EWMA, default 0.2
+}
+
+// AdaptiveThrottle behaves like Throttle but continuously retunes its rate
+// from an EWMA of how long the downstream consumer takes to accept each
+// item: rising latency (the consumer is falling behind) throttles down,
+// falling latency throttles back up, within [MinRate, MaxRate].
+func AdaptiveThrottle[T any](ctx context.Context, p *Pipeline[T], cfg AdaptiveThrottleConfig, buf ...int) *Pipeline[T] {
+	if cfg.EWMAAlpha <= 0 {
+		cfg.EWMAAlpha = 0.2
+	}
+	rate := cfg.InitialRate
+	bucket := newTokenBucket(rate, cfg.Per)
+	out := make(chan Result[T], bufSize(buf))
+
+	go func() {
+		defer close(out)
+		var ewma time.Duration
+		for {
+			var r Result[T]
+			select {
+			case v, ok := <-p.out:
+				if !ok {
+					return
+				}
+				r = v
+			case <-ctx.Done():
+				return
+			}
+
+			if r.Err == nil {
+				if err := bucket.wait(ctx); err != nil {
+					r = Result[T]{Err: err}
+				}
+			}
+
+			start := time.Now()
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+			latency := time.Since(start)
+
+			if ewma == 0 {
+				ewma = latency
+			} else {
+				ewma = time.Duration(cfg.EWMAAlpha*float64(latency) + (1-cfg.EWMAAlpha)*float64(ewma))
+			}
+			rate = nextRate(rate, ewma, cfg)
+			bucket.setRate(rate, cfg.Per)
+		}
+	}()
+
+	return &Pipeline[T]{out: out}
+}
+
+func nextRate(rate int, ewma time.Duration, cfg AdaptiveThrottleConfig) int {
+	minRate := cfg.MinRate
+	if minRate < 1 {
+		minRate = 1
+	}
+	switch {
+	case ewma > cfg.TargetLatency && rate > minRate:
+		rate--
+	case ewma < cfg.TargetLatency/2 && rate < cfg.MaxRate:
+		rate++
+	}
+	return rate
+}
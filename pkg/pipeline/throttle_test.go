@@ -0,0 +1,91 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestThrottleBoundsRate(t *testing.T) {
+	ctx := context.Background()
+	n := 20
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	p := New(ctx, FromSlice(items...))
+	throttled := Throttle(ctx, p, 5, 100*time.Millisecond)
+
+	start := time.Now()
+	var got []int
+	if err := throttled.Run(ctx, Collect(&got)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if len(got) != n {
+		t.Fatalf("got %d items, want %d", len(got), n)
+	}
+	// 20 items at 5/100ms allows an initial burst of 5 "for free", leaving 15
+	// more to be paced in, so the run can't finish much faster than 15 *
+	// (100ms/5) = 300ms.
+	if want := 300 * time.Millisecond; elapsed < want {
+		t.Errorf("ran in %v, want at least %v given the configured rate", elapsed, want)
+	}
+}
+
+func TestThrottleZeroRateReturnsError(t *testing.T) {
+	// Collect returns as soon as it sees an error, so this ctx must be
+	// canceled afterwards or upstream stages would block forever trying to
+	// hand later items to a sink that already stopped reading (see
+	// TestMapPropagatesError).
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := New(ctx, FromSlice(1, 2, 3))
+	throttled := Throttle(ctx, p, 0, time.Second)
+
+	var got []int
+	err := throttled.Run(ctx, Collect(&got))
+	if err == nil {
+		t.Fatal("got nil error, want a rate-must-be-positive error")
+	}
+}
+
+func TestAdaptiveThrottleDeliversAllItems(t *testing.T) {
+	ctx := context.Background()
+	items := make([]int, 10)
+	for i := range items {
+		items[i] = i
+	}
+	p := New(ctx, FromSlice(items...))
+	cfg := AdaptiveThrottleConfig{
+		InitialRate:   2,
+		Per:           100 * time.Millisecond,
+		MinRate:       1,
+		MaxRate:       10,
+		TargetLatency: 5 * time.Millisecond,
+	}
+	throttled := AdaptiveThrottle(ctx, p, cfg)
+
+	var got []int
+	if err := throttled.Run(ctx, Collect(&got)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(items) {
+		t.Fatalf("got %d items, want %d", len(got), len(items))
+	}
+}
+
+func TestTokenBucketWaitRespectsCancellation(t *testing.T) {
+	b := newTokenBucket(1, time.Hour) // effectively never refills within the test
+	if err := b.wait(context.Background()); err != nil {
+		t.Fatalf("first wait: unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+}
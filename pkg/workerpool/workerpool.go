@@ -0,0 +1,292 @@
+// Package workerpool implements a generic worker pool with dynamic sizing,
+// per-job timeouts, retries with backoff, and result futures. It replaces
+// the fixed-size, fire-and-forget pool pattern with one that reports back
+// per-job errors and lets callers tune capacity while it's running.
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrPoolClosed is returned by Submit once Shutdown has been called.
+var ErrPoolClosed = errors.New("workerpool: pool is shut down")
+
+// Job pairs an input with the function that processes it.
+type Job[T, R any] struct {
+	Input T
+	Fn    func(ctx context.Context, input T) (R, error)
+}
+
+// Future is the handle returned by Submit. Wait blocks until the job has
+// run (including any retries) and returns its result or final error.
+type Future[R any] struct {
+	done   chan struct{}
+	result R
+	err    error
+}
+
+func newFuture[R any]() *Future[R] {
+	return &Future[R]{done: make(chan struct{})}
+}
+
+func (f *Future[R]) complete(result R, err error) {
+	f.result = result
+	f.err = err
+	close(f.done)
+}
+
+// Wait blocks until the job completes and returns its result or error.
+func (f *Future[R]) Wait() (R, error) {
+	<-f.done
+	return f.result, f.err
+}
+
+// Config controls pool behavior. A zero JobTimeout disables the per-attempt
+// timeout, a zero MaxRetries disables retries, and a zero BaseBackoff
+// retries without waiting.
+type Config struct {
+	Workers     int
+	QueueSize   int
+	JobTimeout  time.Duration
+	MaxRetries  int
+	BaseBackoff time.Duration
+}
+
+// Metrics is a point-in-time snapshot of pool activity.
+type Metrics struct {
+	QueueDepth    int
+	ActiveWorkers int64
+	Completed     int64
+	Failed        int64
+}
+
+type queuedJob[T, R any] struct {
+	ctx    context.Context
+	job    Job[T, R]
+	future *Future[R]
+}
+
+// Pool runs submitted jobs across a resizable set of workers.
+type Pool[T, R any] struct {
+	cfg    Config
+	jobs   chan queuedJob[T, R]
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	workers  []chan struct{} // per-worker stop signal, closed to drain that worker
+	closed   bool
+	submitWG sync.WaitGroup // in-flight Submit calls that passed the closed check
+
+	activeWorkers int64
+	completed     int64
+	failed        int64
+}
+
+// New creates a pool and starts cfg.Workers workers.
+func New[T, R any](cfg Config) *Pool[T, R] {
+	if cfg.QueueSize < 0 {
+		cfg.QueueSize = 0
+	}
+	p := &Pool[T, R]{
+		cfg:    cfg,
+		jobs:   make(chan queuedJob[T, R], cfg.QueueSize),
+		stopCh: make(chan struct{}),
+	}
+	p.Resize(cfg.Workers)
+	return p
+}
+
+// Submit enqueues job and returns a Future for its result. If ctx is
+// canceled before the job is picked up by a worker, the future resolves
+// with ctx.Err() without ever running the job. Once Shutdown has been
+// called, Submit returns a future that resolves immediately with
+// ErrPoolClosed instead of sending on the (possibly already closed) job
+// channel.
+func (p *Pool[T, R]) Submit(ctx context.Context, job Job[T, R]) *Future[R] {
+	f := newFuture[R]()
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		var zero R
+		f.complete(zero, ErrPoolClosed)
+		return f
+	}
+	p.submitWG.Add(1)
+	p.mu.Unlock()
+	defer p.submitWG.Done()
+
+	qj := queuedJob[T, R]{ctx: ctx, job: job, future: f}
+	select {
+	case p.jobs <- qj:
+	case <-ctx.Done():
+		var zero R
+		f.complete(zero, ctx.Err())
+	}
+	return f
+}
+
+// Resize grows or shrinks the worker count to n without dropping
+// in-flight or already-queued jobs: new workers are simply started, and
+// removed workers finish their current job (if any) before exiting. Once
+// Shutdown has been called, Resize is a no-op — growing the pool after
+// Shutdown has started waiting for workers to exit would re-add to the
+// same WaitGroup Shutdown is waiting on.
+func (p *Pool[T, R]) Resize(n int) {
+	if n < 0 {
+		n = 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	for len(p.workers) < n {
+		stop := make(chan struct{})
+		p.workers = append(p.workers, stop)
+		p.wg.Add(1)
+		go p.runWorker(stop)
+	}
+	for len(p.workers) > n {
+		last := len(p.workers) - 1
+		close(p.workers[last])
+		p.workers = p.workers[:last]
+	}
+}
+
+func (p *Pool[T, R]) runWorker(stop <-chan struct{}) {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-p.stopCh:
+			return
+		default:
+		}
+		select {
+		case <-stop:
+			return
+		case <-p.stopCh:
+			return
+		case qj, ok := <-p.jobs:
+			if !ok {
+				return
+			}
+			atomic.AddInt64(&p.activeWorkers, 1)
+			p.execute(qj)
+			atomic.AddInt64(&p.activeWorkers, -1)
+		}
+	}
+}
+
+func (p *Pool[T, R]) execute(qj queuedJob[T, R]) {
+	if err := qj.ctx.Err(); err != nil {
+		var zero R
+		qj.future.complete(zero, err)
+		return
+	}
+	jobCtx, cancel := mergeCancel(qj.ctx, p.stopCh)
+	defer cancel()
+
+	var result R
+	var err error
+	for attempt := 0; ; attempt++ {
+		result, err = p.runOnce(jobCtx, qj.job)
+		if err == nil || attempt >= p.cfg.MaxRetries {
+			break
+		}
+
+		backoff := p.cfg.BaseBackoff * time.Duration(1<<uint(attempt))
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+			continue
+		case <-jobCtx.Done():
+			timer.Stop()
+			err = jobCtx.Err()
+		}
+		break
+	}
+
+	if err != nil {
+		atomic.AddInt64(&p.failed, 1)
+	} else {
+		atomic.AddInt64(&p.completed, 1)
+	}
+	qj.future.complete(result, err)
+}
+
+func (p *Pool[T, R]) runOnce(ctx context.Context, job Job[T, R]) (R, error) {
+	if p.cfg.JobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.cfg.JobTimeout)
+		defer cancel()
+	}
+	return job.Fn(ctx, job.Input)
+}
+
+// Metrics returns a snapshot of current pool activity.
+func (p *Pool[T, R]) Metrics() Metrics {
+	return Metrics{
+		QueueDepth:    len(p.jobs),
+		ActiveWorkers: atomic.LoadInt64(&p.activeWorkers),
+		Completed:     atomic.LoadInt64(&p.completed),
+		Failed:        atomic.LoadInt64(&p.failed),
+	}
+}
+
+// Shutdown stops accepting new jobs, lets queued and in-flight jobs finish,
+// and returns once all workers have exited or ctx expires first. After
+// Shutdown is called, Submit returns ErrPoolClosed instead of panicking.
+func (p *Pool[T, R]) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	p.submitWG.Wait() // let any Submit that already passed the closed check finish sending
+	close(p.jobs)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop cancels every in-flight job's context and returns once all workers
+// have exited. Unlike Shutdown, queued jobs are not guaranteed to run.
+func (p *Pool[T, R]) Stop() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	close(p.stopCh)
+	p.wg.Wait()
+}
+
+// mergeCancel returns a context canceled when either parent is done or
+// stop is closed.
+func mergeCancel(parent context.Context, stop <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
@@ -0,0 +1,140 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestSubmitWait(t *testing.T) {
+	ctx := context.Background()
+	pool := New[int, int](Config{Workers: 2, QueueSize: 4})
+	defer pool.Shutdown(ctx)
+
+	f := pool.Submit(ctx, Job[int, int]{
+		Input: 5,
+		Fn:    func(ctx context.Context, n int) (int, error) { return n * 2, nil },
+	})
+
+	result, err := f.Wait()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != 10 {
+		t.Errorf("got %d, want 10", result)
+	}
+}
+
+func TestRetryThenSucceed(t *testing.T) {
+	ctx := context.Background()
+	pool := New[int, int](Config{Workers: 1, MaxRetries: 3, BaseBackoff: time.Millisecond})
+	defer pool.Shutdown(ctx)
+
+	var attempts int32
+	f := pool.Submit(ctx, Job[int, int]{
+		Input: 7,
+		Fn: func(ctx context.Context, n int) (int, error) {
+			if atomic.AddInt32(&attempts, 1) < 3 {
+				return 0, errors.New("transient failure")
+			}
+			return n, nil
+		},
+	})
+
+	result, err := f.Wait()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != 7 {
+		t.Errorf("got %d, want 7", result)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("fn called %d times, want 3", got)
+	}
+
+	m := pool.Metrics()
+	if m.Completed != 1 || m.Failed != 0 {
+		t.Errorf("got metrics %+v, want Completed=1, Failed=0", m)
+	}
+}
+
+func TestJobTimeout(t *testing.T) {
+	ctx := context.Background()
+	pool := New[int, int](Config{Workers: 1, JobTimeout: 10 * time.Millisecond})
+	defer pool.Shutdown(ctx)
+
+	f := pool.Submit(ctx, Job[int, int]{
+		Input: 1,
+		Fn: func(ctx context.Context, n int) (int, error) {
+			select {
+			case <-time.After(100 * time.Millisecond):
+				return n, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		},
+	})
+
+	_, err := f.Wait()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestConcurrentSubmitShutdown(t *testing.T) {
+	ctx := context.Background()
+	pool := New[int, int](Config{Workers: 4, QueueSize: 4})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pool.Submit(ctx, Job[int, int]{
+				Input: i,
+				Fn:    func(ctx context.Context, n int) (int, error) { return n, nil },
+			})
+		}(i)
+	}
+
+	if err := pool.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+	wg.Wait()
+}
+
+func TestConcurrentResizeShutdown(t *testing.T) {
+	ctx := context.Background()
+	pool := New[int, int](Config{Workers: 2})
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				pool.Resize(5)
+				pool.Resize(1)
+			}
+		}
+	}()
+
+	if err := pool.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+	close(stop)
+	wg.Wait()
+}